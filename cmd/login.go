@@ -5,7 +5,10 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"time"
 
 	"github.com/pkg/browser"
@@ -14,6 +17,12 @@ import (
 	"github.com/agbcloud/agbcloud-cli/internal/auth"
 	"github.com/agbcloud/agbcloud-cli/internal/client"
 	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/output"
+)
+
+var (
+	loginTokenFile string
+	loginDevice    bool
 )
 
 var LoginCmd = &cobra.Command{
@@ -27,37 +36,73 @@ var LoginCmd = &cobra.Command{
 }
 
 func init() {
-	// No flags needed for login command
+	LoginCmd.Flags().StringVar(&loginTokenFile, "token", "", "log in non-interactively using a token read from the given file, or '-' for stdin")
+	LoginCmd.Flags().BoolVar(&loginDevice, "device", false, "log in using the OAuth device authorization grant, for environments without a browser")
+	LoginCmd.MarkFlagsMutuallyExclusive("token", "device")
+}
+
+// LoginResult is the structured result of a successful login, rendered via
+// internal/output so scripts can consume it with `-o json|yaml|jsonpath=`.
+type LoginResult struct {
+	RequestID string `json:"requestId"`
+	TraceID   string `json:"traceId"`
+	SessionId string `json:"sessionId"`
+	ExpiresAt string `json:"expiresAt"`
 }
 
 func runLogin(cmd *cobra.Command) error {
-	fmt.Println("🔐 Starting AgbCloud authentication...")
+	if loginTokenFile != "" {
+		return runLoginWithToken(loginTokenFile)
+	}
+
+	if loginDevice {
+		return runLoginDevice(cmd)
+	}
+
+	output.Progressf("🔐 Starting AgbCloud authentication...")
 
 	// Create client configuration for OAuth
 	cfg := config.DefaultConfig()
 
 	apiClient := client.NewFromConfig(cfg)
 
-	// Get default callback port (port selection is handled automatically by server)
-	defaultPort := auth.GetCallbackPort()
-	fmt.Printf("📡 Default callback port: %s\n", defaultPort)
+	// Reserve a loopback port up front so there's no gap between picking a
+	// port and binding it in which another process could grab it first.
+	listener, port, err := auth.ReserveLoopbackListener()
+	if err != nil {
+		return fmt.Errorf("failed to reserve a callback port: %w", err)
+	}
+	defer listener.Close()
+	output.Progressf("📡 Reserved callback port: %s", port)
+
+	// Generate PKCE verifier/challenge and a state value binding this
+	// authorization request to the callback that completes it (RFC 7636).
+	codeVerifier, err := auth.GenerateCodeVerifier()
+	if err != nil {
+		return fmt.Errorf("failed to generate PKCE code verifier: %w", err)
+	}
+	codeChallenge := auth.DeriveCodeChallenge(codeVerifier)
+
+	state, err := auth.GenerateState()
+	if err != nil {
+		return fmt.Errorf("failed to generate OAuth state: %w", err)
+	}
 
 	// Create context with timeout for OAuth request
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	fmt.Println("🌐 Requesting OAuth login URL...")
+	output.Progressf("🌐 Requesting OAuth login URL...")
 
-	// First call - Get the OAuth URL without localhostPort parameter
-	response, httpResp, err := apiClient.OAuthAPI.GetLoginProviderURL(ctx, fmt.Sprintf("http://localhost:%s", defaultPort), "CLI", "GOOGLE_LOCALHOST")
+	response, httpResp, err := apiClient.OAuthAPI.GetLoginProviderURLWithPort(ctx, fmt.Sprintf("http://localhost:%s", port), "CLI", "GOOGLE_LOCALHOST", port, codeChallenge, "S256", state)
 	if err != nil {
 		if apiErr, ok := err.(*client.GenericOpenAPIError); ok {
-			fmt.Printf("❌ API Error: %s\n", apiErr.Error())
+			output.Progressf("❌ API Error: %s", apiErr.Error())
 			if httpResp != nil {
-				fmt.Printf("📊 Status Code: %d\n", httpResp.StatusCode)
+				output.Progressf("📊 Status Code: %d", httpResp.StatusCode)
 			}
 			if len(apiErr.Body()) > 0 {
-				fmt.Printf("📄 Response Body: %s\n", string(apiErr.Body()))
+				output.Progressf("📄 Response Body: %s", string(apiErr.Body()))
 			}
 			return fmt.Errorf("failed to get OAuth URL: %s", apiErr.Error())
 		}
@@ -69,76 +114,16 @@ func runLogin(cmd *cobra.Command) error {
 		return fmt.Errorf("OAuth request failed: %s", response.Code)
 	}
 
-	// Check if default port is available
-	var finalPort string
-	var finalResponse client.OAuthLoginProviderResponse
-
-	if !auth.IsPortOccupied(defaultPort) {
-		// Default port is available, use it
-		finalPort = defaultPort
-		finalResponse = response
-		fmt.Printf("✅ Default port %s is available\n", defaultPort)
-	} else {
-		// Default port is occupied, try alternative ports
-		fmt.Printf("⚠️  Default port %s is occupied, trying alternative ports...\n", defaultPort)
-
-		if response.Data.AlternativePorts == "" {
-			return fmt.Errorf("default port %s is occupied and no alternative ports provided", defaultPort)
-		}
-
-		// Select an available port from alternatives
-		selectedPort, err := auth.SelectAvailablePort(defaultPort, response.Data.AlternativePorts)
-		if err != nil {
-			fmt.Printf("❌ Port selection failed:\n")
-			fmt.Printf("   Default port %s is occupied\n", defaultPort)
-			if response.Data.AlternativePorts != "" {
-				fmt.Printf("   Alternative ports provided: %s\n", response.Data.AlternativePorts)
-				fmt.Printf("   All alternative ports are also occupied\n")
-				fmt.Printf("💡 Please free up one of these ports and try again\n")
-			} else {
-				fmt.Printf("   No alternative ports provided by server\n")
-			}
-			return fmt.Errorf("failed to find available port: %v", err)
-		}
-
-		fmt.Printf("🔄 Using alternative port: %s\n", selectedPort)
-
-		// Make second API call with the selected port
-		secondResponse, secondHttpResp, err := apiClient.OAuthAPI.GetLoginProviderURLWithPort(ctx, fmt.Sprintf("http://localhost:%s", selectedPort), "CLI", "GOOGLE_LOCALHOST", selectedPort)
-		if err != nil {
-			if apiErr, ok := err.(*client.GenericOpenAPIError); ok {
-				fmt.Printf("❌ API Error on second call: %s\n", apiErr.Error())
-				if secondHttpResp != nil {
-					fmt.Printf("📊 Status Code: %d\n", secondHttpResp.StatusCode)
-				}
-				if len(apiErr.Body()) > 0 {
-					fmt.Printf("📄 Response Body: %s\n", string(apiErr.Body()))
-				}
-				return fmt.Errorf("failed to get OAuth URL with alternative port: %s", apiErr.Error())
-			}
-			return fmt.Errorf("network error on second call: %v", err)
-		}
-
-		if !secondResponse.Success {
-			return fmt.Errorf("OAuth request with alternative port failed: %s", secondResponse.Code)
-		}
-
-		finalPort = selectedPort
-		finalResponse = secondResponse
-	}
-
-	if finalResponse.Data.InvokeURL == "" {
+	if response.Data.InvokeURL == "" {
 		return fmt.Errorf("received empty OAuth URL from server")
 	}
 
-	fmt.Println("✅ Successfully retrieved OAuth URL!")
-	fmt.Printf("📋 Request ID: %s\n", finalResponse.RequestID)
-	fmt.Printf("🔍 Trace ID: %s\n", finalResponse.TraceID)
-	fmt.Printf("📡 Final callback port: %s\n", finalPort)
-	fmt.Println()
+	output.Progressf("✅ Successfully retrieved OAuth URL!")
+	output.Progressf("📋 Request ID: %s", response.RequestID)
+	output.Progressf("🔍 Trace ID: %s", response.TraceID)
 
 	// Start local callback server
-	fmt.Printf("🚀 Starting local callback server on port %s...\n", finalPort)
+	output.Progressf("🚀 Starting local callback server on port %s...", port)
 
 	// Create context for callback server with longer timeout
 	callbackCtx, callbackCancel := context.WithTimeout(context.Background(), 5*time.Minute)
@@ -149,7 +134,7 @@ func runLogin(cmd *cobra.Command) error {
 	errChan := make(chan error, 1)
 
 	go func() {
-		code, err := auth.StartCallbackServer(callbackCtx, finalPort)
+		code, err := auth.StartCallbackServer(callbackCtx, listener, state)
 		if err != nil {
 			errChan <- err
 			return
@@ -157,113 +142,89 @@ func runLogin(cmd *cobra.Command) error {
 		codeChan <- code
 	}()
 
-	// Give server time to start
-	time.Sleep(100 * time.Millisecond)
-
 	// Display the URL and open browser
-	fmt.Println("🔗 OAuth URL:")
-	fmt.Printf("  %s\n\n", finalResponse.Data.InvokeURL)
-
-	fmt.Println("🌐 Opening the browser for authentication...")
-	fmt.Println()
-	fmt.Println("If the browser doesn't open automatically, please copy and paste the URL above.")
+	output.Progressf("🔗 OAuth URL: %s", response.Data.InvokeURL)
+	output.Progressf("🌐 Opening the browser for authentication...")
+	output.Progressf("If the browser doesn't open automatically, please copy and paste the URL above.")
 
-	err = browser.OpenURL(finalResponse.Data.InvokeURL)
+	err = browser.OpenURL(response.Data.InvokeURL)
 	if err != nil {
-		fmt.Printf("⚠️  Failed to open browser automatically: %v\n", err)
-		fmt.Println("💡 Please copy the URL above and paste it into your browser to complete authentication.")
+		output.Progressf("⚠️  Failed to open browser automatically: %v", err)
+		output.Progressf("💡 Please copy the URL above and paste it into your browser to complete authentication.")
 	} else {
-		fmt.Println("✅ Browser opened successfully!")
+		output.Progressf("✅ Browser opened successfully!")
 	}
 
-	fmt.Println("📝 Please complete the authentication process in your browser.")
-	fmt.Printf("🔄 Waiting for callback on http://localhost:%s/callback...\n", finalPort)
+	output.Progressf("📝 Please complete the authentication process in your browser.")
+	output.Progressf("🔄 Waiting for callback on http://localhost:%s/callback...", port)
 
 	// Wait for callback
 	select {
 	case code := <-codeChan:
-		fmt.Println("✅ Authentication successful!")
-		fmt.Printf("🔑 Received authorization code: %s...\n", code[:min(len(code), 20)])
+		output.Progressf("✅ Authentication successful!")
+		output.Progressf("🔑 Received authorization code: %s...", code[:min(len(code), 20)])
 
 		// Now call LoginTranslate to exchange code for access token
-		fmt.Println("🔄 Exchanging authorization code for access token...")
+		output.Progressf("🔄 Exchanging authorization code for access token...")
 
 		// Create context for LoginTranslate request
 		translateCtx, translateCancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer translateCancel()
 
-		translateResponse, translateHttpResp, err := apiClient.OAuthAPI.LoginTranslateWithPort(translateCtx, "CLI", "GOOGLE_LOCALHOST", code, finalPort)
+		translateResponse, translateHttpResp, err := apiClient.OAuthAPI.LoginTranslateWithPort(translateCtx, "CLI", "GOOGLE_LOCALHOST", code, port, codeVerifier)
 		if err != nil {
 			if apiErr, ok := err.(*client.GenericOpenAPIError); ok {
-				fmt.Printf("❌ LoginTranslate API Error: %s\n", apiErr.Error())
+				output.Progressf("❌ LoginTranslate API Error: %s", apiErr.Error())
 				if translateHttpResp != nil {
-					fmt.Printf("📊 Status Code: %d\n", translateHttpResp.StatusCode)
+					output.Progressf("📊 Status Code: %d", translateHttpResp.StatusCode)
 				}
 				if len(apiErr.Body()) > 0 {
-					fmt.Printf("📄 Response Body: %s\n", string(apiErr.Body()))
+					output.Progressf("📄 Response Body: %s", string(apiErr.Body()))
 				}
 				return fmt.Errorf("failed to exchange code for token: %s", apiErr.Error())
 			}
 			return fmt.Errorf("network error during token exchange: %v", err)
 		}
 
-		// Display detailed response information
-		fmt.Println("\n🎯 LoginTranslate Response Details:")
-		fmt.Printf("📊 HTTP Status Code: %d\n", translateHttpResp.StatusCode)
-		fmt.Printf("✅ Success: %v\n", translateResponse.Success)
-		fmt.Printf("📝 Code: %s\n", translateResponse.Code)
-		fmt.Printf("📋 Request ID: %s\n", translateResponse.RequestID)
-		fmt.Printf("🔍 Trace ID: %s\n", translateResponse.TraceID)
-		fmt.Printf("🌐 HTTP Status Code (from response): %d\n", translateResponse.HTTPStatusCode)
-
-		if translateResponse.Success {
-			fmt.Println("\n🔑 Authentication Token Information:")
-			if translateResponse.Data.LoginToken != "" {
-				fmt.Printf("🎫 Login Token: %s\n", translateResponse.Data.LoginToken)
-			} else {
-				fmt.Println("⚠️  Login Token: (empty)")
-			}
-			if translateResponse.Data.SessionId != "" {
-				fmt.Printf("🆔 Session ID: %s\n", translateResponse.Data.SessionId)
-			} else {
-				fmt.Println("⚠️  Session ID: (empty)")
-			}
-			if translateResponse.Data.KeepAliveToken != "" {
-				fmt.Printf("🔄 Keep Alive Token: %s", translateResponse.Data.KeepAliveToken)
-			} else {
-				fmt.Println("⚠️  Keep Alive Token: (empty)")
-			}
+		if !translateResponse.Success {
+			return fmt.Errorf("token exchange was not successful: %s", translateResponse.Code)
+		}
 
-			// Save tokens to configuration
-			fmt.Println("\n💾 Saving authentication tokens...")
+		// Save tokens to configuration
+		output.Progressf("💾 Saving authentication tokens...")
 
-			config, err := config.GetConfig()
-			if err != nil {
-				fmt.Printf("⚠️  Warning: Failed to load config: %v\n", err)
-				fmt.Println("🎉 You are logged in, but tokens were not saved to config file.")
-				return nil
-			}
+		cfgStore, err := config.GetConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
 
-			err = config.SaveTokens(
-				translateResponse.Data.LoginToken,
-				translateResponse.Data.SessionId,
-				translateResponse.Data.KeepAliveToken,
-				translateResponse.Data.ExpiresAt,
-			)
-			if err != nil {
-				fmt.Printf("⚠️  Warning: Failed to save tokens: %v\n", err)
-				fmt.Println("🎉 You are logged in, but tokens were not saved to config file.")
-				return nil
-			}
+		if err := cfgStore.SaveTokens(
+			translateResponse.Data.LoginToken,
+			translateResponse.Data.SessionId,
+			translateResponse.Data.KeepAliveToken,
+			translateResponse.Data.ExpiresAt,
+		); err != nil {
+			return fmt.Errorf("failed to save tokens: %w", err)
+		}
+
+		output.Progressf("✅ Authentication tokens saved successfully!")
+		output.Progressf("🎉 You are now logged in to AgbCloud!")
 
-			fmt.Println("✅ Authentication tokens saved successfully!")
-			fmt.Println("\n🎉 You are now logged in to AgbCloud!")
-		} else {
-			fmt.Printf("\n❌ Token exchange failed: %s\n", translateResponse.Code)
-			return fmt.Errorf("token exchange was not successful")
+		// Read the tokens back through the TokenSource so the background
+		// refresh path (keep-alive token, expiry skew, cross-process lock)
+		// is exercised from the moment login succeeds, not just by
+		// future commands.
+		tokens, err := auth.NewTokenSource(cfgStore, apiClient).Token(translateCtx)
+		if err != nil {
+			return fmt.Errorf("failed to read back saved tokens: %w", err)
 		}
 
-		return nil
+		return output.Render(&LoginResult{
+			RequestID: translateResponse.RequestID,
+			TraceID:   translateResponse.TraceID,
+			SessionId: tokens.SessionId,
+			ExpiresAt: tokens.ExpiresAt,
+		})
 	case err := <-errChan:
 		return fmt.Errorf("authentication failed: %v", err)
 	case <-callbackCtx.Done():
@@ -278,3 +239,164 @@ func min(a, b int) int {
 	}
 	return b
 }
+
+// tokenFileContents is the shape expected in the file passed to `--token`: a
+// long-lived API token / service-account credential, as issued out-of-band
+// by AgbCloud for CI runners and other non-interactive environments.
+type tokenFileContents struct {
+	LoginToken     string `json:"loginToken"`
+	SessionId      string `json:"sessionId"`
+	KeepAliveToken string `json:"keepAliveToken"`
+	ExpiresAt      string `json:"expiresAt"`
+}
+
+// runLoginWithToken bypasses the OAuth dance entirely, reading a
+// pre-provisioned token from path (or stdin, if path is "-") and writing it
+// into the same config store the browser and device flows use, so
+// downstream commands work unchanged.
+func runLoginWithToken(path string) error {
+	output.Progressf("🔐 Logging in to AgbCloud using a provided token...")
+
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	var tokens tokenFileContents
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return fmt.Errorf("failed to parse token file as JSON: %w", err)
+	}
+
+	if tokens.LoginToken == "" || tokens.SessionId == "" {
+		return fmt.Errorf("token file must contain at least loginToken and sessionId")
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if err := cfg.SaveTokens(tokens.LoginToken, tokens.SessionId, tokens.KeepAliveToken, tokens.ExpiresAt); err != nil {
+		return fmt.Errorf("failed to save tokens: %w", err)
+	}
+
+	output.Progressf("✅ Authentication tokens saved successfully!")
+	output.Progressf("🎉 You are now logged in to AgbCloud!")
+
+	apiClient := client.NewFromConfig(cfg)
+	source := auth.NewTokenSource(cfg, apiClient)
+	savedTokens, err := source.Token(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to read back saved tokens: %w", err)
+	}
+
+	return output.Render(&LoginResult{
+		SessionId: savedTokens.SessionId,
+		ExpiresAt: savedTokens.ExpiresAt,
+	})
+}
+
+// runLoginDevice drives the OAuth 2.0 Device Authorization Grant (RFC 8628)
+// for environments without a browser or loopback network access, such as
+// SSH sessions, CI runners, and containers.
+func runLoginDevice(cmd *cobra.Command) error {
+	output.Progressf("🔐 Starting AgbCloud device authentication...")
+
+	cfg := config.DefaultConfig()
+	apiClient := client.NewFromConfig(cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	deviceResp, httpResp, err := apiClient.OAuthAPI.GetDeviceCode(ctx, "CLI", "GOOGLE_LOCALHOST")
+	if err != nil {
+		if apiErr, ok := err.(*client.GenericOpenAPIError); ok {
+			if httpResp != nil {
+				output.Progressf("📊 Status Code: %d", httpResp.StatusCode)
+			}
+			return fmt.Errorf("failed to start device login: %s", apiErr.Error())
+		}
+		return fmt.Errorf("network error: %v", err)
+	}
+
+	if !deviceResp.Success {
+		return fmt.Errorf("device login request failed: %s", deviceResp.Code)
+	}
+
+	output.Progressf("To complete authentication, visit:")
+	output.Progressf("  %s", deviceResp.Data.VerificationURI)
+	output.Progressf("and enter the code:")
+	output.Progressf("  %s", deviceResp.Data.UserCode)
+	output.Progressf("🔄 Waiting for you to complete authentication in a browser...")
+
+	interval := time.Duration(deviceResp.Data.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	pollCtx, pollCancel := context.WithTimeout(context.Background(), time.Duration(deviceResp.Data.ExpiresIn)*time.Second)
+	defer pollCancel()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-pollCtx.Done():
+			return fmt.Errorf("device authentication timed out, please try again")
+		case <-ticker.C:
+			tokenResp, _, err := apiClient.OAuthAPI.PollDeviceToken(pollCtx, deviceResp.Data.DeviceCode)
+			if err != nil {
+				return fmt.Errorf("network error while polling for token: %w", err)
+			}
+
+			switch tokenResp.Code {
+			case "authorization_pending":
+				continue
+			case "slow_down":
+				interval += 5 * time.Second
+				ticker.Reset(interval)
+				continue
+			}
+
+			if !tokenResp.Success {
+				return fmt.Errorf("device authentication failed: %s", tokenResp.Code)
+			}
+
+			cfgStore, err := config.GetConfig()
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			if err := cfgStore.SaveTokens(
+				tokenResp.Data.LoginToken,
+				tokenResp.Data.SessionId,
+				tokenResp.Data.KeepAliveToken,
+				tokenResp.Data.ExpiresAt,
+			); err != nil {
+				return fmt.Errorf("failed to save tokens: %w", err)
+			}
+
+			output.Progressf("✅ Authentication successful!")
+			output.Progressf("🎉 You are now logged in to AgbCloud!")
+
+			savedTokens, err := auth.NewTokenSource(cfgStore, apiClient).Token(pollCtx)
+			if err != nil {
+				return fmt.Errorf("failed to read back saved tokens: %w", err)
+			}
+
+			return output.Render(&LoginResult{
+				SessionId: savedTokens.SessionId,
+				ExpiresAt: savedTokens.ExpiresAt,
+			})
+		}
+	}
+}
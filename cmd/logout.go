@@ -0,0 +1,43 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/output"
+)
+
+// LogoutResult is the structured result of a successful logout, rendered
+// via internal/output so scripts can consume it with `-o json|yaml`.
+type LogoutResult struct {
+	LoggedOut bool `json:"loggedOut"`
+}
+
+var LogoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Log out of AgbCloud",
+	Long:  "Remove the locally stored AgbCloud authentication tokens",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runLogout(cmd)
+	},
+}
+
+func runLogout(cmd *cobra.Command) error {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if err := cfg.DeleteTokens(); err != nil {
+		return fmt.Errorf("failed to remove stored tokens: %w", err)
+	}
+
+	output.Progressf("✅ You have been logged out of AgbCloud.")
+	return output.Render(&LogoutResult{LoggedOut: true})
+}
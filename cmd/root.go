@@ -0,0 +1,27 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/output"
+)
+
+var outputFormat string
+
+var RootCmd = &cobra.Command{
+	Use:   "agbcloud",
+	Short: "The AgbCloud command-line interface",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return output.SetFormat(outputFormat)
+	},
+}
+
+func init() {
+	RootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "output format: table, json, yaml, or jsonpath=<expr>")
+
+	RootCmd.AddCommand(LoginCmd)
+	RootCmd.AddCommand(LogoutCmd)
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package auth implements the local side of the OAuth loopback flow used by
+// `agbcloud login`: reserving a callback port and running the server that
+// receives the authorization redirect.
+package auth
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ReserveLoopbackListener binds an ephemeral loopback port chosen by the
+// kernel and returns the already-bound listener along with its port,
+// so there's no gap between picking a port and binding it in which another
+// process could grab it first.
+func ReserveLoopbackListener() (net.Listener, string, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to reserve a loopback port: %w", err)
+	}
+
+	port := strconv.Itoa(ln.Addr().(*net.TCPAddr).Port)
+	return ln, port, nil
+}
+
+// StartCallbackServer serves on the given listener until it receives the
+// OAuth provider's redirect with an authorization code, or ctx is done. It
+// validates that the `state` query parameter returned by the provider
+// matches expectedState, rejecting any callback that doesn't with a
+// browser-visible error page; this closes the window for a code-injection
+// attack against the loopback redirect.
+func StartCallbackServer(ctx context.Context, ln net.Listener, expectedState string) (string, error) {
+	codeChan := make(chan string, 1)
+	errChan := make(chan error, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		if errParam := query.Get("error"); errParam != "" {
+			writeCallbackError(w, "Authentication failed: "+html.EscapeString(errParam))
+			errChan <- fmt.Errorf("oauth provider returned error: %s", errParam)
+			return
+		}
+
+		if query.Get("state") != expectedState {
+			writeCallbackError(w, "Authentication failed: state mismatch. Please restart the login flow.")
+			errChan <- fmt.Errorf("callback state %q does not match expected state", query.Get("state"))
+			return
+		}
+
+		code := query.Get("code")
+		if code == "" {
+			writeCallbackError(w, "Authentication failed: missing authorization code.")
+			errChan <- fmt.Errorf("callback did not include an authorization code")
+			return
+		}
+
+		fmt.Fprint(w, "<html><body><h1>Login successful</h1><p>You can close this window and return to the terminal.</p></body></html>")
+		codeChan <- code
+	})
+
+	server := &http.Server{Handler: mux}
+	go func() {
+		_ = server.Serve(ln)
+	}()
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	select {
+	case code := <-codeChan:
+		return code, nil
+	case err := <-errChan:
+		return "", err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func writeCallbackError(w http.ResponseWriter, message string) {
+	w.WriteHeader(http.StatusBadRequest)
+	fmt.Fprintf(w, "<html><body><h1>Login failed</h1><p>%s</p></body></html>", message)
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestStartCallbackServerRejectsStateMismatch(t *testing.T) {
+	ln, port, err := ReserveLoopbackListener()
+	if err != nil {
+		t.Fatalf("ReserveLoopbackListener() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resultCh := make(chan error, 1)
+	go func() {
+		_, err := StartCallbackServer(ctx, ln, "expected-state")
+		resultCh <- err
+	}()
+
+	resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%s/callback?code=somecode&state=wrong-state", port))
+	if err != nil {
+		t.Fatalf("GET /callback error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+
+	if serverErr := <-resultCh; serverErr == nil {
+		t.Error("StartCallbackServer() returned nil error for a state mismatch")
+	}
+}
+
+func TestStartCallbackServerAcceptsMatchingState(t *testing.T) {
+	ln, port, err := ReserveLoopbackListener()
+	if err != nil {
+		t.Fatalf("ReserveLoopbackListener() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resultCh := make(chan struct {
+		code string
+		err  error
+	}, 1)
+	go func() {
+		code, err := StartCallbackServer(ctx, ln, "expected-state")
+		resultCh <- struct {
+			code string
+			err  error
+		}{code, err}
+	}()
+
+	resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%s/callback?code=somecode&state=expected-state", port))
+	if err != nil {
+		t.Fatalf("GET /callback error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	result := <-resultCh
+	if result.err != nil {
+		t.Fatalf("StartCallbackServer() error = %v", result.err)
+	}
+	if result.code != "somecode" {
+		t.Errorf("StartCallbackServer() code = %q, want %q", result.code, "somecode")
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+)
+
+// GenerateCodeVerifier returns a cryptographically random PKCE code_verifier
+// as defined by RFC 7636 section 4.1 (a base64url-encoded 32-byte value).
+func GenerateCodeVerifier() (string, error) {
+	return randomBase64URLString(32)
+}
+
+// DeriveCodeChallenge computes the S256 PKCE code_challenge for verifier, as
+// defined by RFC 7636 section 4.2: BASE64URL(SHA256(verifier)).
+func DeriveCodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// GenerateState returns a random value used to bind the authorization
+// request to the callback that completes it, preventing CSRF/code
+// injection against the loopback redirect.
+func GenerateState() (string, error) {
+	return randomBase64URLString(16)
+}
+
+func randomBase64URLString(numBytes int) (string, error) {
+	buf := make([]byte, numBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
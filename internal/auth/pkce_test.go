@@ -0,0 +1,52 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import "testing"
+
+// TestDeriveCodeChallenge checks against the worked example from RFC 7636
+// appendix B, so a regression can't silently change which hash/encoding is
+// used without a test failing.
+func TestDeriveCodeChallenge(t *testing.T) {
+	const verifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	const wantChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+
+	got := DeriveCodeChallenge(verifier)
+	if got != wantChallenge {
+		t.Fatalf("DeriveCodeChallenge(%q) = %q, want %q", verifier, got, wantChallenge)
+	}
+}
+
+func TestGenerateCodeVerifier(t *testing.T) {
+	a, err := GenerateCodeVerifier()
+	if err != nil {
+		t.Fatalf("GenerateCodeVerifier() error = %v", err)
+	}
+	b, err := GenerateCodeVerifier()
+	if err != nil {
+		t.Fatalf("GenerateCodeVerifier() error = %v", err)
+	}
+
+	if a == b {
+		t.Fatalf("GenerateCodeVerifier() returned the same value twice: %q", a)
+	}
+	if len(a) == 0 {
+		t.Fatal("GenerateCodeVerifier() returned an empty string")
+	}
+}
+
+func TestGenerateState(t *testing.T) {
+	a, err := GenerateState()
+	if err != nil {
+		t.Fatalf("GenerateState() error = %v", err)
+	}
+	b, err := GenerateState()
+	if err != nil {
+		t.Fatalf("GenerateState() error = %v", err)
+	}
+
+	if a == b {
+		t.Fatalf("GenerateState() returned the same value twice: %q", a)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+// refreshSkew is how long before actual expiry a token is considered
+// expired, giving in-flight requests enough headroom to complete.
+const refreshSkew = 60 * time.Second
+
+// lockTimeout bounds how long TokenSource waits to acquire the refresh lock
+// before giving up, in case a previous holder crashed without releasing it.
+const lockTimeout = 10 * time.Second
+
+// TokenSource yields a valid AgbCloud access token, transparently
+// refreshing it via the keep-alive token when it's close to expiry.
+// Modeled after oauth2.TokenSource.
+type TokenSource struct {
+	cfg       *config.Config
+	apiClient *client.APIClient
+}
+
+// NewTokenSource builds a TokenSource backed by cfg's token store and using
+// apiClient to call the refresh endpoint.
+func NewTokenSource(cfg *config.Config, apiClient *client.APIClient) *TokenSource {
+	return &TokenSource{cfg: cfg, apiClient: apiClient}
+}
+
+// Token returns the current set of tokens, refreshing them first if the
+// access token is within refreshSkew of expiring.
+func (s *TokenSource) Token(ctx context.Context) (*config.Tokens, error) {
+	tokens, err := s.cfg.GetTokens()
+	if err != nil {
+		return nil, err
+	}
+
+	if tokenIsFresh(tokens) {
+		return tokens, nil
+	}
+
+	return s.refresh(ctx)
+}
+
+// tokenIsFresh reports whether tokens can be used without refreshing first.
+// A missing or unparseable ExpiresAt is treated as "does not expire" rather
+// than "already expired": long-lived tokens supplied via `--token` (chunk0-2)
+// have no expiry at all, and treating that as staleness would force a
+// refresh call with no keep-alive token to refresh with.
+func tokenIsFresh(tokens *config.Tokens) bool {
+	if tokens.ExpiresAt == "" {
+		return true
+	}
+	expiresAt, err := time.Parse(time.RFC3339, tokens.ExpiresAt)
+	if err != nil {
+		return true
+	}
+	return time.Until(expiresAt) > refreshSkew
+}
+
+// refresh calls the refresh endpoint and persists the result, serializing
+// concurrent refreshes across CLI invocations with a file lock on the
+// config directory so two processes racing to refresh don't both hit the
+// refresh endpoint with the same (single-use) keep-alive token.
+func (s *TokenSource) refresh(ctx context.Context) (*config.Tokens, error) {
+	unlock, err := acquireRefreshLock(s.cfg, lockTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// Re-read after acquiring the lock: another process may have already
+	// refreshed while we were waiting.
+	tokens, err := s.cfg.GetTokens()
+	if err != nil {
+		return nil, err
+	}
+	if tokenIsFresh(tokens) {
+		return tokens, nil
+	}
+
+	resp, _, err := s.apiClient.OAuthAPI.RefreshToken(ctx, tokens.KeepAliveToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh token: %w", err)
+	}
+	if !resp.Success {
+		return nil, fmt.Errorf("token refresh was not successful: %s", resp.Code)
+	}
+
+	if err := s.cfg.SaveTokens(
+		resp.Data.LoginToken,
+		resp.Data.SessionId,
+		resp.Data.KeepAliveToken,
+		resp.Data.ExpiresAt,
+	); err != nil {
+		return nil, fmt.Errorf("failed to save refreshed tokens: %w", err)
+	}
+
+	return s.cfg.GetTokens()
+}
+
+// staleLockAge bounds how long a refresh lock can be held before a waiter is
+// entitled to steal it, covering the case where the holder was killed or
+// panicked without removing the lock file.
+const staleLockAge = 30 * time.Second
+
+// acquireRefreshLock takes an exclusive, cross-process lock on the config
+// directory's refresh lock file, retrying until it succeeds or timeout
+// elapses. It returns a function that releases the lock. The lock file
+// records the holder's PID and acquisition time so a waiter can steal a
+// lock left behind by a dead or stuck process instead of blocking forever.
+func acquireRefreshLock(cfg *config.Config, timeout time.Duration) (func(), error) {
+	if err := os.MkdirAll(cfg.ConfigDir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	lockPath := filepath.Join(cfg.ConfigDir, "refresh.lock")
+	deadline := time.Now().Add(timeout)
+
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			fmt.Fprintf(f, "%d %d\n", os.Getpid(), time.Now().Unix())
+			_ = f.Close()
+			return func() { _ = os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to create refresh lock: %w", err)
+		}
+
+		if staleRefreshLock(lockPath) {
+			_ = os.Remove(lockPath)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for refresh lock held by another agbcloud process")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// staleRefreshLock reports whether the lock file at lockPath was left behind
+// by a process that is no longer alive, or is simply older than
+// staleLockAge. An unreadable or malformed lock file is treated as stale so
+// a corrupt lock can't wedge refreshes forever.
+func staleRefreshLock(lockPath string) bool {
+	data, err := os.ReadFile(lockPath)
+	if err != nil {
+		return true
+	}
+
+	var pid int
+	var acquiredAt int64
+	if _, err := fmt.Sscanf(string(data), "%d %d", &pid, &acquiredAt); err != nil {
+		return true
+	}
+
+	if time.Since(time.Unix(acquiredAt, 0)) > staleLockAge {
+		return true
+	}
+
+	return !processAlive(pid)
+}
+
+// processAlive reports whether pid refers to a live process. On Unix,
+// sending signal 0 performs error checking without actually signaling.
+func processAlive(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return process.Signal(syscall.Signal(0)) == nil
+}
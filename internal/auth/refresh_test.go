@@ -0,0 +1,163 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+func newTestConfig(t *testing.T, apiBaseURL string) *config.Config {
+	t.Helper()
+	t.Setenv("AGBCLOUD_CREDENTIAL_STORE", "file")
+	return &config.Config{APIBaseURL: apiBaseURL, ConfigDir: t.TempDir()}
+}
+
+func TestTokenIsFresh(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresAt string
+		want      bool
+	}{
+		{"empty expiresAt never expires", "", true},
+		{"unparseable expiresAt treated as fresh", "not-a-time", true},
+		{"far in the future", time.Now().Add(time.Hour).Format(time.RFC3339), true},
+		{"within the refresh skew", time.Now().Add(30 * time.Second).Format(time.RFC3339), false},
+		{"already expired", time.Now().Add(-time.Hour).Format(time.RFC3339), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tokenIsFresh(&config.Tokens{ExpiresAt: tt.expiresAt})
+			if got != tt.want {
+				t.Errorf("tokenIsFresh(%q) = %v, want %v", tt.expiresAt, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenSourceReturnsFreshTokenWithoutRefreshing(t *testing.T) {
+	var refreshCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&refreshCalls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	cfg := newTestConfig(t, server.URL)
+	if err := cfg.SaveTokens("login-token", "session-id", "keep-alive-token", ""); err != nil {
+		t.Fatalf("SaveTokens() error = %v", err)
+	}
+
+	source := NewTokenSource(cfg, client.NewFromConfig(cfg))
+	tokens, err := source.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	if tokens.LoginToken != "login-token" {
+		t.Errorf("LoginToken = %q, want %q", tokens.LoginToken, "login-token")
+	}
+	if calls := atomic.LoadInt32(&refreshCalls); calls != 0 {
+		t.Errorf("refresh endpoint called %d times, want 0", calls)
+	}
+}
+
+func TestTokenSourceRefreshesExpiredToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("keepAliveToken"); got != "keep-alive-token" {
+			t.Errorf("keepAliveToken = %q, want %q", got, "keep-alive-token")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"success": true,
+			"data": {
+				"loginToken": "new-login-token",
+				"sessionId": "new-session-id",
+				"keepAliveToken": "new-keep-alive-token",
+				"expiresAt": "` + time.Now().Add(time.Hour).Format(time.RFC3339) + `"
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	cfg := newTestConfig(t, server.URL)
+	expired := time.Now().Add(-time.Hour).Format(time.RFC3339)
+	if err := cfg.SaveTokens("login-token", "session-id", "keep-alive-token", expired); err != nil {
+		t.Fatalf("SaveTokens() error = %v", err)
+	}
+
+	source := NewTokenSource(cfg, client.NewFromConfig(cfg))
+	tokens, err := source.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	if tokens.LoginToken != "new-login-token" {
+		t.Errorf("LoginToken = %q, want %q", tokens.LoginToken, "new-login-token")
+	}
+}
+
+func TestAcquireRefreshLockStealsDeadHolder(t *testing.T) {
+	cfg := newTestConfig(t, "")
+
+	unlock, err := acquireRefreshLock(cfg, lockTimeout)
+	if err != nil {
+		t.Fatalf("acquireRefreshLock() error = %v", err)
+	}
+	unlock()
+
+	lockPath := filepath.Join(cfg.ConfigDir, "refresh.lock")
+	// Simulate a holder that was killed without cleaning up: a lock file
+	// naming a PID that can't possibly be alive.
+	if err := os.WriteFile(lockPath, []byte("999999999 "+strconv.FormatInt(time.Now().Unix(), 10)), 0o600); err != nil {
+		t.Fatalf("failed to write stale lock file: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		unlock, err := acquireRefreshLock(cfg, lockTimeout)
+		if err == nil {
+			unlock()
+		}
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("acquireRefreshLock() did not steal dead holder's lock: %v", err)
+		}
+	case <-time.After(lockTimeout):
+		t.Fatal("acquireRefreshLock() blocked on a dead holder's lock instead of stealing it")
+	}
+}
+
+func TestAcquireRefreshLockStealsOldLock(t *testing.T) {
+	cfg := newTestConfig(t, "")
+
+	lockPath := filepath.Join(cfg.ConfigDir, "refresh.lock")
+	if err := os.MkdirAll(cfg.ConfigDir, 0o700); err != nil {
+		t.Fatalf("failed to create config dir: %v", err)
+	}
+	staleTime := time.Now().Add(-2 * staleLockAge).Unix()
+	lockContents := strconv.Itoa(os.Getpid()) + " " + strconv.FormatInt(staleTime, 10)
+	if err := os.WriteFile(lockPath, []byte(lockContents), 0o600); err != nil {
+		t.Fatalf("failed to write stale lock file: %v", err)
+	}
+
+	unlock, err := acquireRefreshLock(cfg, lockTimeout)
+	if err != nil {
+		t.Fatalf("acquireRefreshLock() did not steal an old lock: %v", err)
+	}
+	unlock()
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package client provides a thin HTTP client for the AgbCloud REST API.
+package client
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+// APIClient groups together the service clients for each area of the
+// AgbCloud API.
+type APIClient struct {
+	cfg *config.Config
+
+	httpClient *http.Client
+
+	OAuthAPI *OAuthAPIService
+	ImageAPI *ImageAPIService
+}
+
+// NewFromConfig builds an APIClient whose requests are targeted at the API
+// base URL configured in cfg.
+func NewFromConfig(cfg *config.Config) *APIClient {
+	c := &APIClient{
+		cfg:        cfg,
+		httpClient: &http.Client{},
+	}
+
+	c.OAuthAPI = &OAuthAPIService{client: c}
+	c.ImageAPI = &ImageAPIService{client: c}
+
+	return c
+}
+
+func (c *APIClient) baseURL() string {
+	return c.cfg.APIBaseURL
+}
+
+// GenericOpenAPIError is returned for any non-2xx response from the
+// AgbCloud API.
+type GenericOpenAPIError struct {
+	message string
+	body    []byte
+}
+
+func (e *GenericOpenAPIError) Error() string {
+	return e.message
+}
+
+// Body returns the raw response body associated with the error, if any.
+func (e *GenericOpenAPIError) Body() []byte {
+	return e.body
+}
+
+func newAPIError(resp *http.Response, body []byte) *GenericOpenAPIError {
+	return &GenericOpenAPIError{
+		message: fmt.Sprintf("API returned status %d", resp.StatusCode),
+		body:    body,
+	}
+}
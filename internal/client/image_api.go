@@ -0,0 +1,109 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ImageAPIService talks to the image management endpoints of the AgbCloud
+// API.
+type ImageAPIService struct {
+	client *APIClient
+}
+
+// Image describes a single image returned by ListImages.
+type Image struct {
+	ImageID   string `json:"imageId"`
+	ImageName string `json:"imageName"`
+}
+
+// ImageOperationResponse is returned by operations that don't return a
+// richer payload, such as StopImage.
+type ImageOperationResponse struct {
+	Success   bool        `json:"success"`
+	Code      string      `json:"code"`
+	RequestID string      `json:"requestId"`
+	TraceID   string      `json:"traceId"`
+	Data      interface{} `json:"data"`
+}
+
+// ListImagesResponse is returned by ListImages.
+type ListImagesResponse struct {
+	Success   bool   `json:"success"`
+	Code      string `json:"code"`
+	RequestID string `json:"requestId"`
+	TraceID   string `json:"traceId"`
+	Data      struct {
+		Images []Image `json:"images"`
+		Total  int     `json:"total"`
+	} `json:"data"`
+}
+
+// StopImage deactivates the image identified by imageId on behalf of the
+// authenticated session.
+func (s *ImageAPIService) StopImage(ctx context.Context, loginToken, sessionId, imageId string) (ImageOperationResponse, *http.Response, error) {
+	var result ImageOperationResponse
+
+	if loginToken == "" || sessionId == "" || imageId == "" {
+		return result, nil, fmt.Errorf("loginToken, sessionId, and imageId are required")
+	}
+
+	query := url.Values{}
+	query.Set("imageId", imageId)
+
+	httpResp, err := s.do(ctx, loginToken, sessionId, "/image/stop", query, &result)
+	return result, httpResp, err
+}
+
+// ListImages lists images owned by the authenticated session, filtered by
+// owner ("User" or "System"), paginated by page/pageSize.
+func (s *ImageAPIService) ListImages(ctx context.Context, loginToken, sessionId, owner string, page, pageSize int) (ListImagesResponse, *http.Response, error) {
+	var result ListImagesResponse
+
+	query := url.Values{}
+	query.Set("owner", owner)
+	query.Set("page", fmt.Sprintf("%d", page))
+	query.Set("pageSize", fmt.Sprintf("%d", pageSize))
+
+	httpResp, err := s.do(ctx, loginToken, sessionId, "/image/list", query, &result)
+	return result, httpResp, err
+}
+
+func (s *ImageAPIService) do(ctx context.Context, loginToken, sessionId, path string, query url.Values, out interface{}) (*http.Response, error) {
+	reqURL := fmt.Sprintf("%s%s?%s", s.client.baseURL(), path, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+loginToken)
+	req.Header.Set("X-Session-Id", sessionId)
+
+	resp, err := s.client.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp, newAPIError(resp, body)
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return resp, fmt.Errorf("failed to parse response body: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,199 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// OAuthAPIService talks to the OAuth endpoints of the AgbCloud API.
+type OAuthAPIService struct {
+	client *APIClient
+}
+
+// OAuthLoginProviderResponse is returned by GetLoginProviderURLWithPort.
+type OAuthLoginProviderResponse struct {
+	Success   bool   `json:"success"`
+	Code      string `json:"code"`
+	RequestID string `json:"requestId"`
+	TraceID   string `json:"traceId"`
+	Data      struct {
+		InvokeURL string `json:"invokeUrl"`
+	} `json:"data"`
+}
+
+// OAuthDeviceCodeResponse is returned by GetDeviceCode.
+type OAuthDeviceCodeResponse struct {
+	Success   bool   `json:"success"`
+	Code      string `json:"code"`
+	RequestID string `json:"requestId"`
+	TraceID   string `json:"traceId"`
+	Data      struct {
+		DeviceCode      string `json:"deviceCode"`
+		UserCode        string `json:"userCode"`
+		VerificationURI string `json:"verificationUri"`
+		Interval        int    `json:"interval"`
+		ExpiresIn       int    `json:"expiresIn"`
+	} `json:"data"`
+}
+
+// OAuthDeviceTokenResponse is returned by PollDeviceToken. While the user
+// has not yet completed the flow, Code is "authorization_pending" (keep
+// polling at the current interval) or "slow_down" (increase the interval);
+// any other Code is terminal.
+type OAuthDeviceTokenResponse struct {
+	Success bool   `json:"success"`
+	Code    string `json:"code"`
+	Data    struct {
+		LoginToken     string `json:"loginToken"`
+		SessionId      string `json:"sessionId"`
+		KeepAliveToken string `json:"keepAliveToken"`
+		ExpiresAt      string `json:"expiresAt"`
+	} `json:"data"`
+}
+
+// OAuthLoginTranslateResponse is returned by LoginTranslateWithPort.
+type OAuthLoginTranslateResponse struct {
+	Success        bool   `json:"success"`
+	Code           string `json:"code"`
+	RequestID      string `json:"requestId"`
+	TraceID        string `json:"traceId"`
+	HTTPStatusCode int    `json:"httpStatusCode"`
+	Data           struct {
+		LoginToken     string `json:"loginToken"`
+		SessionId      string `json:"sessionId"`
+		KeepAliveToken string `json:"keepAliveToken"`
+		ExpiresAt      string `json:"expiresAt"`
+	} `json:"data"`
+}
+
+// GetLoginProviderURLWithPort requests an OAuth authorization URL pinned to
+// the given loopback port. codeChallenge/codeChallengeMethod/state
+// implement PKCE (RFC 7636) and CSRF protection for the loopback redirect.
+// Since the port is reserved up front via auth.ReserveLoopbackListener,
+// any port in the ephemeral range is accepted and no fallback call is
+// needed.
+func (s *OAuthAPIService) GetLoginProviderURLWithPort(ctx context.Context, redirectURL, clientType, provider, port, codeChallenge, codeChallengeMethod, state string) (OAuthLoginProviderResponse, *http.Response, error) {
+	var result OAuthLoginProviderResponse
+
+	query := url.Values{}
+	query.Set("redirectUrl", redirectURL)
+	query.Set("clientType", clientType)
+	query.Set("provider", provider)
+	query.Set("codeChallenge", codeChallenge)
+	query.Set("codeChallengeMethod", codeChallengeMethod)
+	query.Set("state", state)
+	query.Set("localhostPort", port)
+
+	httpResp, err := s.doGet(ctx, "/oauth/login-url", query, &result)
+	return result, httpResp, err
+}
+
+// LoginTranslateWithPort exchanges an authorization code returned by the
+// loopback callback for a set of AgbCloud tokens. codeVerifier is the PKCE
+// verifier that must match the code_challenge sent to
+// GetLoginProviderURLWithPort.
+func (s *OAuthAPIService) LoginTranslateWithPort(ctx context.Context, clientType, provider, code, port, codeVerifier string) (OAuthLoginTranslateResponse, *http.Response, error) {
+	var result OAuthLoginTranslateResponse
+
+	query := url.Values{}
+	query.Set("clientType", clientType)
+	query.Set("provider", provider)
+	query.Set("code", code)
+	query.Set("localhostPort", port)
+	query.Set("codeVerifier", codeVerifier)
+
+	httpResp, err := s.doGet(ctx, "/oauth/login-translate", query, &result)
+	return result, httpResp, err
+}
+
+// OAuthRefreshTokenResponse is returned by RefreshToken.
+type OAuthRefreshTokenResponse struct {
+	Success   bool   `json:"success"`
+	Code      string `json:"code"`
+	RequestID string `json:"requestId"`
+	TraceID   string `json:"traceId"`
+	Data      struct {
+		LoginToken     string `json:"loginToken"`
+		SessionId      string `json:"sessionId"`
+		KeepAliveToken string `json:"keepAliveToken"`
+		ExpiresAt      string `json:"expiresAt"`
+	} `json:"data"`
+}
+
+// RefreshToken exchanges a keep-alive token (returned alongside the login
+// token by LoginTranslate) for a fresh set of tokens, without requiring the
+// user to go through the OAuth flow again.
+func (s *OAuthAPIService) RefreshToken(ctx context.Context, keepAliveToken string) (OAuthRefreshTokenResponse, *http.Response, error) {
+	var result OAuthRefreshTokenResponse
+
+	query := url.Values{}
+	query.Set("keepAliveToken", keepAliveToken)
+
+	httpResp, err := s.doGet(ctx, "/oauth/refresh-token", query, &result)
+	return result, httpResp, err
+}
+
+// GetDeviceCode begins an OAuth 2.0 Device Authorization Grant (RFC 8628),
+// returning the device_code to poll with and the user_code/verification_uri
+// to display to the user.
+func (s *OAuthAPIService) GetDeviceCode(ctx context.Context, clientType, provider string) (OAuthDeviceCodeResponse, *http.Response, error) {
+	var result OAuthDeviceCodeResponse
+
+	query := url.Values{}
+	query.Set("clientType", clientType)
+	query.Set("provider", provider)
+
+	httpResp, err := s.doGet(ctx, "/oauth/device/code", query, &result)
+	return result, httpResp, err
+}
+
+// PollDeviceToken polls for the result of a device authorization grant
+// started by GetDeviceCode. Callers should keep polling at the interval
+// returned by GetDeviceCode until the response reports Success or a terminal
+// Code.
+func (s *OAuthAPIService) PollDeviceToken(ctx context.Context, deviceCode string) (OAuthDeviceTokenResponse, *http.Response, error) {
+	var result OAuthDeviceTokenResponse
+
+	query := url.Values{}
+	query.Set("deviceCode", deviceCode)
+
+	httpResp, err := s.doGet(ctx, "/oauth/device/token", query, &result)
+	return result, httpResp, err
+}
+
+func (s *OAuthAPIService) doGet(ctx context.Context, path string, query url.Values, out interface{}) (*http.Response, error) {
+	reqURL := fmt.Sprintf("%s%s?%s", s.client.baseURL(), path, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	resp, err := s.client.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp, newAPIError(resp, body)
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return resp, fmt.Errorf("failed to parse response body: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Config holds the runtime configuration for the CLI, including where the
+// API lives and where on disk tokens are persisted.
+type Config struct {
+	APIBaseURL string `json:"apiBaseUrl"`
+	ConfigDir  string `json:"-"`
+
+	store CredentialStore
+}
+
+// Tokens is the set of credentials returned by the OAuth login flow and
+// persisted between CLI invocations.
+type Tokens struct {
+	LoginToken     string `json:"loginToken"`
+	SessionId      string `json:"sessionId"`
+	KeepAliveToken string `json:"keepAliveToken"`
+	ExpiresAt      string `json:"expiresAt"`
+}
+
+const defaultAPIBaseURL = "https://api.agbcloud.com"
+
+// DefaultConfig returns a Config populated with built-in defaults, used when
+// no config file is present yet.
+func DefaultConfig() *Config {
+	return &Config{
+		APIBaseURL: defaultAPIBaseURL,
+		ConfigDir:  defaultConfigDir(),
+	}
+}
+
+// GetConfig loads the config file from disk, falling back to defaults for
+// any field that isn't set.
+func GetConfig() (*Config, error) {
+	cfg := DefaultConfig()
+
+	path := cfg.configFilePath()
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	cfg.ConfigDir = defaultConfigDir()
+
+	return cfg, nil
+}
+
+func defaultConfigDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+	return filepath.Join(home, ".agbcloud")
+}
+
+func (c *Config) configFilePath() string {
+	return filepath.Join(c.ConfigDir, "config.json")
+}
+
+// credentialStore lazily resolves the CredentialStore backend for this
+// Config, picking it via AGBCLOUD_CREDENTIAL_STORE (see store.go) and
+// migrating any pre-existing plaintext tokens into it on first use.
+func (c *Config) credentialStore() (CredentialStore, error) {
+	if c.store != nil {
+		return c.store, nil
+	}
+
+	store, err := newCredentialStore(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrateLegacyTokens(c, store); err != nil {
+		return nil, err
+	}
+
+	c.store = store
+	return store, nil
+}
+
+// SaveTokens persists the OAuth tokens returned by LoginTranslate through
+// the configured credential store so that subsequent commands can
+// authenticate without re-running the login flow.
+func (c *Config) SaveTokens(loginToken, sessionId, keepAliveToken, expiresAt string) error {
+	store, err := c.credentialStore()
+	if err != nil {
+		return err
+	}
+
+	return store.SaveTokens(&Tokens{
+		LoginToken:     loginToken,
+		SessionId:      sessionId,
+		KeepAliveToken: keepAliveToken,
+		ExpiresAt:      expiresAt,
+	})
+}
+
+// GetTokens loads the persisted OAuth tokens from the configured credential
+// store.
+func (c *Config) GetTokens() (*Tokens, error) {
+	store, err := c.credentialStore()
+	if err != nil {
+		return nil, err
+	}
+
+	return store.GetTokens()
+}
+
+// DeleteTokens removes any persisted OAuth tokens from the configured
+// credential store, used by `agbcloud logout`.
+func (c *Config) DeleteTokens() error {
+	store, err := c.credentialStore()
+	if err != nil {
+		return err
+	}
+
+	return store.DeleteTokens()
+}
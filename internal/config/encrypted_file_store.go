@@ -0,0 +1,137 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// encryptedFileStore AES-GCM-seals tokens under a key derived from a
+// machine identifier, for headless Linux hosts that have neither a
+// graphical Keychain nor a running Secret Service to back a real keyring.
+type encryptedFileStore struct {
+	path string
+	key  [32]byte
+}
+
+func newEncryptedFileStore(cfg *Config) (*encryptedFileStore, error) {
+	key, err := machineDerivedKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return &encryptedFileStore{
+		path: filepath.Join(cfg.ConfigDir, "tokens.enc"),
+		key:  key,
+	}, nil
+}
+
+// machineDerivedKey hashes a host-specific identifier into an AES-256 key.
+// This only raises the bar above plaintext-on-disk for a single machine;
+// it is not a substitute for a real secret store.
+func machineDerivedKey() ([32]byte, error) {
+	var key [32]byte
+
+	id, err := machineID()
+	if err != nil {
+		return key, err
+	}
+
+	return sha256.Sum256([]byte("agbcloud-cli:" + id)), nil
+}
+
+func machineID() (string, error) {
+	for _, path := range []string{"/etc/machine-id", "/var/lib/dbus/machine-id"} {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			return strings.TrimSpace(string(data)), nil
+		}
+	}
+	return "", fmt.Errorf("no machine identifier available for encrypted-file credential storage")
+}
+
+func (s *encryptedFileStore) gcm() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(s.key[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+func (s *encryptedFileStore) SaveTokens(tokens *Tokens) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	plaintext, err := json.Marshal(tokens)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tokens: %w", err)
+	}
+
+	gcm, err := s.gcm()
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := gcm.Seal(nonce, nonce, plaintext, nil)
+	if err := os.WriteFile(s.path, sealed, 0o600); err != nil {
+		return fmt.Errorf("failed to write encrypted tokens file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *encryptedFileStore) GetTokens() (*Tokens, error) {
+	sealed, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("not logged in: no tokens found, run 'agbcloud login' first")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read encrypted tokens file: %w", err)
+	}
+
+	gcm, err := s.gcm()
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := gcm.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil, fmt.Errorf("encrypted tokens file is corrupt")
+	}
+	nonce, ciphertext := sealed[:nonceSize], sealed[nonceSize:]
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt tokens (wrong machine, or file corrupt): %w", err)
+	}
+
+	var tokens Tokens
+	if err := json.Unmarshal(plaintext, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to parse decrypted tokens: %w", err)
+	}
+
+	return &tokens, nil
+}
+
+func (s *encryptedFileStore) DeleteTokens() error {
+	if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove encrypted tokens file: %w", err)
+	}
+	return nil
+}
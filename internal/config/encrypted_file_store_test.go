@@ -0,0 +1,94 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func newTestEncryptedFileStore(t *testing.T) *encryptedFileStore {
+	t.Helper()
+
+	cfg := &Config{ConfigDir: t.TempDir()}
+	store, err := newEncryptedFileStore(cfg)
+	if err != nil {
+		t.Skipf("no machine identifier available for encrypted-file storage: %v", err)
+	}
+	return store
+}
+
+func TestEncryptedFileStoreRoundTrip(t *testing.T) {
+	store := newTestEncryptedFileStore(t)
+
+	want := &Tokens{
+		LoginToken:     "login-token",
+		SessionId:      "session-id",
+		KeepAliveToken: "keep-alive-token",
+		ExpiresAt:      "2030-01-01T00:00:00Z",
+	}
+
+	if err := store.SaveTokens(want); err != nil {
+		t.Fatalf("SaveTokens() error = %v", err)
+	}
+
+	got, err := store.GetTokens()
+	if err != nil {
+		t.Fatalf("GetTokens() error = %v", err)
+	}
+
+	if *got != *want {
+		t.Fatalf("GetTokens() = %+v, want %+v", got, want)
+	}
+
+	// The tokens must not be recoverable as plaintext from the file on disk.
+	raw, err := os.ReadFile(store.path)
+	if err != nil {
+		t.Fatalf("failed to read encrypted file: %v", err)
+	}
+	if strings.Contains(string(raw), want.LoginToken) {
+		t.Fatal("encrypted tokens file contains the plaintext login token")
+	}
+}
+
+func TestEncryptedFileStoreRejectsCorruptCiphertext(t *testing.T) {
+	store := newTestEncryptedFileStore(t)
+
+	if err := store.SaveTokens(&Tokens{LoginToken: "login-token"}); err != nil {
+		t.Fatalf("SaveTokens() error = %v", err)
+	}
+
+	raw, err := os.ReadFile(store.path)
+	if err != nil {
+		t.Fatalf("failed to read encrypted file: %v", err)
+	}
+	raw[len(raw)-1] ^= 0xFF
+	if err := os.WriteFile(store.path, raw, 0o600); err != nil {
+		t.Fatalf("failed to write corrupted file: %v", err)
+	}
+
+	if _, err := store.GetTokens(); err == nil {
+		t.Fatal("GetTokens() succeeded on corrupted ciphertext, want error")
+	}
+}
+
+func TestEncryptedFileStoreDeleteTokens(t *testing.T) {
+	store := newTestEncryptedFileStore(t)
+
+	if err := store.SaveTokens(&Tokens{LoginToken: "login-token"}); err != nil {
+		t.Fatalf("SaveTokens() error = %v", err)
+	}
+	if err := store.DeleteTokens(); err != nil {
+		t.Fatalf("DeleteTokens() error = %v", err)
+	}
+	if _, err := store.GetTokens(); err == nil {
+		t.Fatal("GetTokens() succeeded after DeleteTokens(), want error")
+	}
+
+	// Deleting again (nothing left to delete) must not error.
+	if err := store.DeleteTokens(); err != nil {
+		t.Fatalf("DeleteTokens() on already-deleted store error = %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// fileStore persists tokens as plaintext JSON on disk. It's the original
+// backend and remains the fallback for platforms without a usable keyring
+// or encrypted-file support.
+type fileStore struct {
+	path string
+}
+
+func newFileStore(cfg *Config) *fileStore {
+	return &fileStore{path: filepath.Join(cfg.ConfigDir, "tokens.json")}
+}
+
+func (s *fileStore) SaveTokens(tokens *Tokens) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal tokens: %w", err)
+	}
+
+	if err := os.WriteFile(s.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write tokens file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *fileStore) GetTokens() (*Tokens, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("not logged in: no tokens found, run 'agbcloud login' first")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tokens file: %w", err)
+	}
+
+	var tokens Tokens
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to parse tokens file: %w", err)
+	}
+
+	return &tokens, nil
+}
+
+func (s *fileStore) DeleteTokens() error {
+	if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove tokens file: %w", err)
+	}
+	return nil
+}
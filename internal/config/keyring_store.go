@@ -0,0 +1,73 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/zalando/go-keyring"
+)
+
+// keyringService/keyringUser address the single credential this CLI stores
+// in the OS keyring (macOS Keychain, Windows Credential Manager, or Secret
+// Service on Linux).
+const (
+	keyringService = "agbcloud-cli"
+	keyringUser    = "default"
+)
+
+// keyringStore persists tokens in the OS-native secret store via
+// go-keyring.
+type keyringStore struct{}
+
+func newKeyringStore() *keyringStore {
+	return &keyringStore{}
+}
+
+// available reports whether a secret service backend is reachable on this
+// machine, used to decide whether to prefer the keyring during
+// auto-detection.
+func (s *keyringStore) available() bool {
+	_, err := keyring.Get(keyringService, keyringUser)
+	return err == nil || errors.Is(err, keyring.ErrNotFound)
+}
+
+func (s *keyringStore) SaveTokens(tokens *Tokens) error {
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tokens: %w", err)
+	}
+
+	if err := keyring.Set(keyringService, keyringUser, string(data)); err != nil {
+		return fmt.Errorf("failed to save tokens to keyring: %w", err)
+	}
+
+	return nil
+}
+
+func (s *keyringStore) GetTokens() (*Tokens, error) {
+	data, err := keyring.Get(keyringService, keyringUser)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return nil, fmt.Errorf("not logged in: no tokens found, run 'agbcloud login' first")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tokens from keyring: %w", err)
+	}
+
+	var tokens Tokens
+	if err := json.Unmarshal([]byte(data), &tokens); err != nil {
+		return nil, fmt.Errorf("failed to parse tokens from keyring: %w", err)
+	}
+
+	return &tokens, nil
+}
+
+func (s *keyringStore) DeleteTokens() error {
+	if err := keyring.Delete(keyringService, keyringUser); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return fmt.Errorf("failed to delete tokens from keyring: %w", err)
+	}
+	return nil
+}
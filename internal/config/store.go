@@ -0,0 +1,78 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"os"
+)
+
+// CredentialStore persists and retrieves OAuth tokens. Implementations
+// trade off portability (file) against protection of the bearer
+// LoginToken at rest (keyring, encrypted-file).
+type CredentialStore interface {
+	GetTokens() (*Tokens, error)
+	SaveTokens(tokens *Tokens) error
+	DeleteTokens() error
+}
+
+// credentialStoreEnvVar selects the backend explicitly, overriding
+// auto-detection. Recognized values: "keyring", "file", "encrypted".
+const credentialStoreEnvVar = "AGBCLOUD_CREDENTIAL_STORE"
+
+// newCredentialStore picks a CredentialStore for cfg based on
+// AGBCLOUD_CREDENTIAL_STORE, auto-detecting the best available backend for
+// the current machine when it isn't set.
+func newCredentialStore(cfg *Config) (CredentialStore, error) {
+	switch backend := os.Getenv(credentialStoreEnvVar); backend {
+	case "keyring":
+		return newKeyringStore(), nil
+	case "file":
+		return newFileStore(cfg), nil
+	case "encrypted":
+		return newEncryptedFileStore(cfg)
+	case "":
+		return autoDetectStore(cfg)
+	default:
+		return nil, fmt.Errorf("unknown %s %q: want keyring, file, or encrypted", credentialStoreEnvVar, backend)
+	}
+}
+
+// autoDetectStore prefers the OS keyring (macOS Keychain, Windows
+// Credential Manager, Secret Service on Linux) when one is reachable,
+// falling back to an encrypted file for headless Linux hosts without a
+// secret service, and finally to the plaintext file for anything else.
+func autoDetectStore(cfg *Config) (CredentialStore, error) {
+	if ks := newKeyringStore(); ks.available() {
+		return ks, nil
+	}
+
+	if store, err := newEncryptedFileStore(cfg); err == nil {
+		return store, nil
+	}
+
+	return newFileStore(cfg), nil
+}
+
+// migrateLegacyTokens moves tokens from the plaintext file store into dst
+// the first time a non-file backend is selected, so existing logins
+// survive the switch without forcing a re-login.
+func migrateLegacyTokens(cfg *Config, dst CredentialStore) error {
+	if _, isFileStore := dst.(*fileStore); isFileStore {
+		return nil
+	}
+
+	legacy := newFileStore(cfg)
+	tokens, err := legacy.GetTokens()
+	if err != nil {
+		// Nothing to migrate.
+		return nil
+	}
+
+	if err := dst.SaveTokens(tokens); err != nil {
+		return fmt.Errorf("failed to migrate existing tokens: %w", err)
+	}
+
+	return legacy.DeleteTokens()
+}
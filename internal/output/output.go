@@ -0,0 +1,114 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package output renders command results in the format requested via
+// `--output`/`-o` (table, json, yaml, or jsonpath=<expr>), and routes
+// progress chatter to the right stream so structured output composes
+// cleanly with jq, shell pipelines, and CI systems.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
+)
+
+// Format selects how a command's final result is rendered.
+type Format string
+
+const (
+	// Table is the default, human-oriented format.
+	Table Format = "table"
+	// JSON renders the result as indented JSON.
+	JSON Format = "json"
+	// YAML renders the result as YAML.
+	YAML Format = "yaml"
+	// JSONPath renders the result of evaluating a dot-path expression
+	// against the result's JSON representation, e.g. "jsonpath=.sessionId".
+	JSONPath Format = "jsonpath"
+)
+
+var (
+	format       = Table
+	jsonPathExpr string
+)
+
+// SetFormat parses the value of `--output`/`-o` and records it as the
+// active format for subsequent Progressf/Render calls.
+func SetFormat(value string) error {
+	switch {
+	case value == "" || value == string(Table):
+		format = Table
+	case value == string(JSON):
+		format = JSON
+	case value == string(YAML):
+		format = YAML
+	case strings.HasPrefix(value, "jsonpath="):
+		format = JSONPath
+		jsonPathExpr = strings.TrimPrefix(value, "jsonpath=")
+	default:
+		return fmt.Errorf("unsupported --output value %q: want table, json, yaml, or jsonpath=<expr>", value)
+	}
+	return nil
+}
+
+// IsStructured reports whether the active format is machine-readable
+// (json, yaml, jsonpath), as opposed to the human-oriented table format.
+func IsStructured() bool {
+	return format != Table
+}
+
+// Progressf prints a progress/status message. In structured output modes
+// it always goes to stderr, keeping stdout reserved for the final result.
+// In table mode it goes to stdout, with emoji stripped when stdout isn't a
+// terminal (e.g. when redirected to a file or another process).
+func Progressf(msg string, args ...interface{}) {
+	line := fmt.Sprintf(msg, args...)
+
+	if IsStructured() {
+		fmt.Fprintln(os.Stderr, line)
+		return
+	}
+
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		line = stripEmoji(line)
+	}
+	fmt.Fprintln(os.Stdout, line)
+}
+
+// Render writes result to stdout in the active format. Table mode prints
+// one "Field: value" line per exported field; json/yaml/jsonpath encode
+// result's JSON representation.
+func Render(result interface{}) error {
+	switch format {
+	case JSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case YAML:
+		enc := yaml.NewEncoder(os.Stdout)
+		defer enc.Close()
+		return enc.Encode(result)
+	case JSONPath:
+		return renderJSONPath(result, jsonPathExpr)
+	default:
+		return renderTable(result)
+	}
+}
+
+// stripEmoji removes the small set of status emoji this CLI prefixes
+// progress lines with, so output piped to a file or another process stays
+// ASCII-clean.
+func stripEmoji(s string) string {
+	replacer := strings.NewReplacer(
+		"🔐 ", "", "📡 ", "", "🌐 ", "", "✅ ", "", "⚠️  ", "", "❌ ", "",
+		"📊 ", "", "📄 ", "", "🔄 ", "", "📋 ", "", "🔍 ", "", "🚀 ", "",
+		"🔗 ", "", "📝 ", "", "🎯 ", "", "🎫 ", "", "🆔 ", "", "🎉 ", "",
+		"🔑 ", "", "💾 ", "", "💡 ", "",
+	)
+	return strings.TrimSpace(replacer.Replace(s))
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// renderTable prints one "Field: value" line per exported field of
+// result, in declaration order. It's intentionally simple: this CLI's
+// results are small, flat structs, not the kind of tabular list that
+// warrants column alignment.
+func renderTable(result interface{}) error {
+	v := reflect.ValueOf(result)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		fmt.Fprintln(os.Stdout, result)
+		return nil
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fmt.Fprintf(os.Stdout, "%s: %v\n", field.Name, v.Field(i).Interface())
+	}
+	return nil
+}
+
+// renderJSONPath evaluates a minimal dot-path expression (e.g.
+// ".sessionId" or ".data.expiresAt") against result's JSON representation
+// and prints the matched value. It supports plain field traversal, which
+// covers this CLI's flat result structs; it isn't a full JSONPath
+// implementation.
+func renderJSONPath(result interface{}, expr string) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal result: %w", err)
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to decode result: %w", err)
+	}
+
+	value, err := evalDotPath(doc, expr)
+	if err != nil {
+		return err
+	}
+
+	if s, ok := value.(string); ok {
+		fmt.Fprintln(os.Stdout, s)
+		return nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal jsonpath result: %w", err)
+	}
+	fmt.Fprintln(os.Stdout, string(encoded))
+	return nil
+}
+
+func evalDotPath(doc interface{}, expr string) (interface{}, error) {
+	expr = strings.TrimPrefix(expr, "$")
+	expr = strings.Trim(expr, ".")
+	if expr == "" {
+		return doc, nil
+	}
+
+	current := doc
+	for _, part := range strings.Split(expr, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("jsonpath: %q is not an object", part)
+		}
+		value, ok := m[part]
+		if !ok {
+			return nil, fmt.Errorf("jsonpath: field %q not found", part)
+		}
+		current = value
+	}
+	return current, nil
+}